@@ -0,0 +1,46 @@
+package otelwrap
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// callerInfo is the file/line/function name resolved from a caller's PC.
+type callerInfo struct {
+	File string
+	Line int
+	Func string
+}
+
+// callerCache memoizes callerInfo by PC, since runtime.CallersFrames'
+// FuncForPC-equivalent lookup and the strings.Split it takes to trim the
+// module path are wasted work once a given call site has already been
+// resolved once.
+var callerCache sync.Map // map[uintptr]callerInfo
+
+// cachedCaller resolves pc to a callerInfo, using the cache when possible.
+func cachedCaller(pc uintptr) callerInfo {
+	if v, ok := callerCache.Load(pc); ok {
+		return v.(callerInfo)
+	}
+	info := resolveCaller(pc)
+	callerCache.Store(pc, info)
+	return info
+}
+
+func resolveCaller(pc uintptr) callerInfo {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.Func == nil {
+		return callerInfo{
+			File: "unknown",
+			Func: "unknown",
+		}
+	}
+	parts := strings.Split(frame.Function, "/")
+	return callerInfo{
+		File: frame.File,
+		Line: frame.Line,
+		Func: parts[len(parts)-1],
+	}
+}
@@ -0,0 +1,53 @@
+package otelwrap
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger receives a structured log record for every span event and recorded
+// error, so traces and logs can be correlated in the backend. Implement
+// this to bridge zerolog, zap, or another structured logger instead of the
+// slog bridge WithLogger wires up automatically.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, kv ...attribute.KeyValue)
+}
+
+// defaultLogger mirrors span events and errors into a structured log, if
+// configured via Init's WithLogger/WithLoggerBackend. Nil by default, so
+// AddEventKV/RecordError stay as cheap as before when no logger is wired up.
+var defaultLogger Logger
+
+// slogLogger adapts a *slog.Logger to Logger.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Log(ctx context.Context, level slog.Level, msg string, kv ...attribute.KeyValue) {
+	attrs := make([]slog.Attr, 0, len(kv))
+	for _, v := range kv {
+		attrs = append(attrs, slog.String(string(v.Key), v.Value.Emit()))
+	}
+	s.l.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logEvent mirrors a span event or error into defaultLogger, tagging it with
+// the span's trace_id/span_id so it can be correlated with the trace. It is
+// a no-op when no logger has been configured.
+func logEvent(span trace.Span, level slog.Level, msg string, kv ...attribute.KeyValue) {
+	if defaultLogger == nil {
+		return
+	}
+	sc := span.SpanContext()
+	full := append([]attribute.KeyValue{}, kv...)
+	if sc.HasTraceID() {
+		full = append(full, attribute.String("trace_id", sc.TraceID().String()))
+	}
+	if sc.HasSpanID() {
+		full = append(full, attribute.String("span_id", sc.SpanID().String()))
+	}
+	defaultLogger.Log(context.Background(), level, msg, full...)
+}
@@ -0,0 +1,109 @@
+package otelwrap
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// config holds the state built up by Option values passed to Init.
+type config struct {
+	tracerProvider trace.TracerProvider
+	tracerName     string
+	tracerVersion  string
+	resource       *resource.Resource
+	propagator     propagation.TextMapPropagator
+	logger         Logger
+}
+
+// Option configures Init.
+type Option func(*config)
+
+// WithTracerProvider uses tp instead of building a TracerProvider from
+// scratch. Use this when the application already manages its own SDK
+// TracerProvider, for example one wired up by InitOTLPHTTP.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithTracerName sets the name and version recorded against the default
+// tracer, matching the instrumentation name/version reported alongside
+// spans.
+func WithTracerName(name, version string) Option {
+	return func(c *config) {
+		c.tracerName = name
+		c.tracerVersion = version
+	}
+}
+
+// WithResource attaches r to the TracerProvider built by Init. Ignored if
+// WithTracerProvider is also given, since the provider's resource is then
+// already fixed.
+func WithResource(r *resource.Resource) Option {
+	return func(c *config) {
+		c.resource = r
+	}
+}
+
+// WithPropagator registers p as the propagator used by Export/Import.
+// Defaults to TraceContext+Baggage.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// WithLogger mirrors span events and recorded errors into l, in addition to
+// attaching them to the span, tagged with the span's trace_id/span_id so
+// the log line can be correlated with the trace. Use WithLoggerBackend
+// instead to bridge a logger other than slog.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = slogLogger{l: l}
+	}
+}
+
+// WithLoggerBackend mirrors span events and recorded errors into l, the
+// same as WithLogger, for callers bridging zerolog, zap, or another
+// structured logger that implements Logger instead of slog.
+func WithLoggerBackend(l Logger) Option {
+	return func(c *config) {
+		c.logger = l
+	}
+}
+
+// Init configures the package default tracer and, unless WithTracerProvider
+// is given, registers a bare SDK TracerProvider with no exporter. Call this
+// once during application startup before any Start* call.
+func Init(opts ...Option) {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tp := c.tracerProvider
+	if tp == nil {
+		res := c.resource
+		if res == nil {
+			res = resource.Default()
+		}
+		tp = sdktrace.NewTracerProvider(sdktrace.WithResource(res))
+	}
+	otel.SetTracerProvider(tp)
+
+	prop := c.propagator
+	if prop == nil {
+		prop = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	otel.SetTextMapPropagator(prop)
+
+	defaultLogger = c.logger
+
+	defaultTracer = newTracer(tp, c.tracerName, c.tracerVersion)
+}
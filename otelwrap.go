@@ -17,14 +17,35 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-// provider used for all traces.
-var provider = otel.GetTracerProvider().Tracer("")
+// defaultTracer is used by the package level Start* functions. It is
+// replaced by Init or by InitOTLPHTTP.
+var defaultTracer = NewTracer("")
 
 // noopSpan does nothing.
 var noopSpan = &Span{
 	noop: true,
 }
 
+// Tracer starts spans. Unlike the package level Start* functions, a Tracer
+// is independent of the global default, so callers can keep one Tracer per
+// subsystem with its own name instead of sharing a single global tracer.
+type Tracer struct {
+	provider trace.Tracer
+}
+
+// NewTracer returns a Tracer that creates spans under name. It uses
+// whatever trace.TracerProvider is currently registered with otel, so it
+// should be created after Init (or otel.SetTracerProvider) has run.
+func NewTracer(name string) *Tracer {
+	return newTracer(otel.GetTracerProvider(), name, "")
+}
+
+func newTracer(tp trace.TracerProvider, name, version string) *Tracer {
+	return &Tracer{
+		provider: tp.Tracer(name, trace.WithInstrumentationVersion(version)),
+	}
+}
+
 // Span of a trace.
 type Span struct {
 	// noop is a span that is a no-op.
@@ -63,77 +84,127 @@ func (s *Span) End() {
 	s.span.End()
 }
 
-// RecordError records an error in the span.
-func (s *Span) RecordError(err error) {
-	if s.noop {
-		return
-	}
-	s.span.RecordError(err)
-}
-
 // StartInternal trace within a process. Params show in the span name.
 func StartInternal(ctx context.Context, params ...string) (context.Context, *Span) {
-	return start(ctx, trace.SpanKindInternal, params...)
+	return defaultTracer.startSpan(ctx, trace.SpanKindInternal, params)
 }
 
 // StartClient trace when calling another process. Params show in the span name.
 func StartClient(ctx context.Context, params ...string) (context.Context, *Span) {
-	return start(ctx, trace.SpanKindClient, params...)
+	return defaultTracer.startSpan(ctx, trace.SpanKindClient, params)
 }
 
 // StartConsumer trace when consuming from a pub/sub system. Params show in the
 // span name.
 func StartConsumer(ctx context.Context, params ...string) (context.Context, *Span) {
-	return start(ctx, trace.SpanKindConsumer, params...)
+	return defaultTracer.startSpan(ctx, trace.SpanKindConsumer, params)
 }
 
 // StartProducer trace when sending to a pub/sub system. Params show in the span
 // name.
 func StartProducer(ctx context.Context, params ...string) (context.Context, *Span) {
-	return start(ctx, trace.SpanKindProducer, params...)
+	return defaultTracer.startSpan(ctx, trace.SpanKindProducer, params)
 }
 
 // StartServer trace when another process is calling us. Params show in the span
 // name.
 func StartServer(ctx context.Context, params ...string) (context.Context, *Span) {
-	return start(ctx, trace.SpanKindServer, params...)
+	return defaultTracer.startSpan(ctx, trace.SpanKindServer, params)
+}
+
+// StartInternal trace within a process. Params show in the span name.
+func (t *Tracer) StartInternal(ctx context.Context, params ...string) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindInternal, params)
+}
+
+// StartClient trace when calling another process. Params show in the span name.
+func (t *Tracer) StartClient(ctx context.Context, params ...string) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindClient, params)
+}
+
+// StartConsumer trace when consuming from a pub/sub system. Params show in the
+// span name.
+func (t *Tracer) StartConsumer(ctx context.Context, params ...string) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindConsumer, params)
+}
+
+// StartProducer trace when sending to a pub/sub system. Params show in the span
+// name.
+func (t *Tracer) StartProducer(ctx context.Context, params ...string) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindProducer, params)
 }
 
-func start(ctx context.Context, kind trace.SpanKind, params ...string) (context.Context, *Span) {
-	info := caller(3)
+// StartServer trace when another process is calling us. Params show in the span
+// name.
+func (t *Tracer) StartServer(ctx context.Context, params ...string) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindServer, params)
+}
+
+// startSpan is the shared implementation behind every Start* entry point,
+// both the package level sugar and the *WithOptions variants in
+// start_options.go. Every caller of startSpan must be exactly one frame
+// above it, since it walks the stack itself with a skip count tuned for
+// that shape, so it keeps resolving to the application code that asked for
+// the span. Wrappers that sit between application code and this call (for
+// example otelwrapkafka's StartProducer) should add WithCallerSkip per extra
+// frame they introduce, via startConfig.callerSkip.
+//
+// The span is started under a cheap static name so that, whether the
+// tracer is the otel no-op tracer or a real one whose sampler drops the
+// span, the case is indistinguishable up front: neither needs the caller
+// resolved or the fmt.Sprintf-heavy span name built. Only once
+// span.IsRecording() confirms the span is actually going to be exported do
+// we pay for that work; otherwise we hand back the shared noopSpan so no
+// *Span wrapping the non-recording span gets allocated.
+func (t *Tracer) startSpan(ctx context.Context, kind trace.SpanKind, params []string, opts ...StartOption) (context.Context, *Span) {
+	var c startConfig
+	for _, opt := range opts {
+		opt.applyStart(&c)
+	}
+	if c.remoteParent != nil {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, *c.remoteParent)
+	}
+
+	spanOpts := append([]trace.SpanStartOption{trace.WithSpanKind(kind)}, c.spanOpts...)
+	ctx, span := t.provider.Start(ctx, kindName(kind), spanOpts...)
+
+	if !span.IsRecording() {
+		return ctx, noopSpan
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3+c.callerSkip, pcs[:])
+	info := cachedCaller(pcs[0])
+
 	var parts []string
 	for _, param := range params {
 		parts = append(parts, fmt.Sprintf("%q", param))
 	}
-	name := fmt.Sprintf("%s(%s)", info.Func, strings.Join(parts, ", "))
-	ctx, span := provider.Start(ctx, name, trace.WithSpanKind(kind))
+	span.SetName(fmt.Sprintf("%s(%s)", info.Func, strings.Join(parts, ", ")))
 	span.SetAttributes(attribute.KeyValue{
 		Key:   "file",
 		Value: attribute.StringValue(fmt.Sprintf("%s:%d", info.File, info.Line)),
 	})
-	return ctx, newSpan(span)
-}
 
-type callerInfo struct {
-	File string
-	Line int
-	Func string
+	return ctx, newSpan(span)
 }
 
-func caller(n int) callerInfo {
-	pc, file, line, ok := runtime.Caller(n)
-	if !ok {
-		return callerInfo{
-			File: "unknown",
-			Func: "unknown",
-		}
-	}
-	fn := runtime.FuncForPC(pc)
-	parts := strings.Split(fn.Name(), "/")
-	return callerInfo{
-		File: file,
-		Line: line,
-		Func: parts[len(parts)-1],
+// kindName is the cheap static span name startSpan uses before it knows
+// whether the span will be recorded.
+func kindName(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindInternal:
+		return "internal"
+	case trace.SpanKindClient:
+		return "client"
+	case trace.SpanKindServer:
+		return "server"
+	case trace.SpanKindProducer:
+		return "producer"
+	case trace.SpanKindConsumer:
+		return "consumer"
+	default:
+		return "span"
 	}
 }
 
@@ -167,4 +238,3 @@ func (m carrier) Keys() []string {
 	}
 	return keys
 }
-
@@ -0,0 +1,58 @@
+package otelwrap
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+)
+
+// BenchmarkStartInternal_Noop measures the fast path against an explicit
+// no-op TracerProvider: startSpan should see an already non-recording span
+// and hand back the shared noopSpan without resolving a caller or
+// allocating a *Span.
+func BenchmarkStartInternal_Noop(b *testing.B) {
+	tracer := newTracer(nooptrace.NewTracerProvider(), "bench", "")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, span := tracer.StartInternal(ctx, "a", "b")
+		span.End()
+	}
+}
+
+// BenchmarkStartInternal_NeverSampled measures the fast path against a real
+// SDK TracerProvider whose sampler drops every span: startSpan still has to
+// call provider.Start, but should skip caller resolution and the
+// fmt.Sprintf-heavy span name once IsRecording() comes back false.
+func BenchmarkStartInternal_NeverSampled(b *testing.B) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+	tracer := newTracer(tp, "bench", "")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, span := tracer.StartInternal(ctx, "a", "b")
+		span.End()
+	}
+}
+
+// BenchmarkStartInternal_Sampled measures the recording path, where the
+// caller must be resolved and the span name/file attribute formatted. The
+// first iteration pays for resolveCaller; the rest hit callerCache.
+func BenchmarkStartInternal_Sampled(b *testing.B) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	tracer := newTracer(tp, "bench", "")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, span := tracer.StartInternal(ctx, "a", "b")
+		span.End()
+	}
+}
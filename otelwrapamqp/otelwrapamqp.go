@@ -0,0 +1,89 @@
+// Package otelwrapamqp propagates trace context through AMQP message table
+// headers and traces publishing/consuming messages, built on otelwrap.
+package otelwrapamqp
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sbunce/otelwrap"
+)
+
+// TableCarrier adapts an amqp.Table to propagation.TextMapCarrier, so a
+// trace context can be propagated through AMQP message headers without
+// allocating an intermediate map. It holds a pointer to the table so Set
+// can lazily allocate it, since amqp.Table (a plain map) is commonly nil on
+// a freshly built amqp.Publishing.
+type TableCarrier struct {
+	table *amqp.Table
+}
+
+// NewTableCarrier wraps table so otelwrap.InjectInto/ExtractFrom can read
+// and write trace context directly against it. table may point at a nil
+// amqp.Table; Set allocates it on first write.
+func NewTableCarrier(table *amqp.Table) TableCarrier {
+	return TableCarrier{table: table}
+}
+
+// Get implements propagation.TextMapCarrier.
+func (t TableCarrier) Get(key string) string {
+	if *t.table == nil {
+		return ""
+	}
+	value, ok := (*t.table)[key]
+	if !ok {
+		return ""
+	}
+	s, _ := value.(string)
+	return s
+}
+
+// Set implements propagation.TextMapCarrier.
+func (t TableCarrier) Set(key, value string) {
+	if *t.table == nil {
+		*t.table = amqp.Table{}
+	}
+	(*t.table)[key] = value
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (t TableCarrier) Keys() []string {
+	keys := make([]string, 0, len(*t.table))
+	for key := range *t.table {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// StartProducer traces publishing a message to exchange/routingKey. It sets
+// messaging semconv attributes and injects the trace context into headers
+// so the consumer can continue the trace. headers may point at a nil
+// amqp.Table; it is allocated lazily if the propagator has anything to
+// write.
+func StartProducer(ctx context.Context, exchange, routingKey string, headers *amqp.Table) (context.Context, *otelwrap.Span) {
+	ctx, span := otelwrap.StartProducerWithOptions(ctx, []string{exchange, routingKey}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.destination", exchange),
+		attribute.String("messaging.operation", "publish"),
+	)
+	otelwrap.InjectInto(ctx, NewTableCarrier(headers))
+	return ctx, span
+}
+
+// StartConsumer traces receiving a message delivered via exchange/
+// routingKey. It extracts the producer's trace context from headers before
+// starting the span, so the consumer span is linked to the producer that
+// sent the message. headers may point at a nil amqp.Table.
+func StartConsumer(ctx context.Context, exchange, routingKey string, headers *amqp.Table) (context.Context, *otelwrap.Span) {
+	ctx = otelwrap.ExtractFrom(ctx, NewTableCarrier(headers))
+	ctx, span := otelwrap.StartConsumerWithOptions(ctx, []string{exchange, routingKey}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.destination", exchange),
+		attribute.String("messaging.operation", "receive"),
+	)
+	return ctx, span
+}
@@ -0,0 +1,150 @@
+// Package otelwrapgrpc provides gRPC client and server interceptors built on
+// otelwrap, so RPC instrumentation shares the same Span type and file:line
+// attribute as application level spans.
+package otelwrapgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/sbunce/otelwrap"
+)
+
+// UnaryServerInterceptor starts a server span for every unary RPC, importing
+// the caller's trace context from the incoming metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := otelwrap.StartServerWithOptions(importMetadata(ctx), []string{info.FullMethod}, otelwrap.WithCallerSkip(1))
+		defer span.End()
+		span.SetAttributes(semconv.RPCMethod(info.FullMethod))
+
+		resp, err := handler(ctx, req)
+		recordResult(span, err)
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor starts a client span for every unary RPC, exporting
+// the current trace context into the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := otelwrap.StartClientWithOptions(ctx, []string{method}, otelwrap.WithCallerSkip(1))
+		defer span.End()
+		span.SetAttributes(semconv.RPCMethod(method))
+
+		err := invoker(exportMetadata(ctx), method, req, reply, cc, opts...)
+		recordResult(span, err)
+		return err
+	}
+}
+
+// StreamServerInterceptor starts a server span wrapping the lifetime of a
+// streaming RPC, importing the caller's trace context from the incoming
+// metadata.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := otelwrap.StartServerWithOptions(importMetadata(ss.Context()), []string{info.FullMethod}, otelwrap.WithCallerSkip(1))
+		defer span.End()
+		span.SetAttributes(semconv.RPCMethod(info.FullMethod))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		recordResult(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor starts a client span wrapping the lifetime of a
+// streaming RPC, exporting the current trace context into the outgoing
+// metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := otelwrap.StartClientWithOptions(ctx, []string{method}, otelwrap.WithCallerSkip(1))
+		span.SetAttributes(semconv.RPCMethod(method))
+
+		stream, err := streamer(exportMetadata(ctx), desc, cc, method, opts...)
+		if err != nil {
+			span.RecordError(err)
+			span.End()
+			return nil, err
+		}
+		return &clientStream{ClientStream: stream, span: span, desc: desc}, nil
+	}
+}
+
+// recordResult maps a gRPC handler error onto the span's status.
+func recordResult(span *otelwrap.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+}
+
+// importMetadata extracts an incoming trace context, if any, from ctx's gRPC
+// metadata.
+func importMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return otelwrap.Import(ctx, metadataToMap(md))
+}
+
+// exportMetadata injects the current trace context into ctx's outgoing gRPC
+// metadata.
+func exportMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+	for key, value := range otelwrap.Export(ctx) {
+		md.Set(key, value)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// metadataToMap flattens gRPC metadata into the map[string]string that
+// otelwrap.Import expects, keeping only the first value of any repeated key.
+func metadataToMap(md metadata.MD) map[string]string {
+	index := make(map[string]string, len(md))
+	for key, values := range md {
+		if len(values) > 0 {
+			index[key] = values[0]
+		}
+	}
+	return index
+}
+
+// serverStream swaps in a context carrying the server span.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// clientStream ends the client span when the stream finishes.
+type clientStream struct {
+	grpc.ClientStream
+	span *otelwrap.Span
+	desc *grpc.StreamDesc
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	// Without server streaming, the single response ends the stream.
+	if err != nil || !s.desc.ServerStreams {
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.span.RecordError(err)
+		}
+		s.span.End()
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+// Package otelwraphttp provides net/http middleware built on otelwrap, so
+// HTTP instrumentation shares the same Span type and file:line attribute as
+// application level spans.
+package otelwraphttp
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/sbunce/otelwrap"
+)
+
+// Handler wraps next, starting a server span for every request. It imports
+// an incoming TraceContext/Baggage via otelwrap.Import, and sets the
+// http.request.method, http.route, and http.response.status_code
+// attributes. Responses with a 5xx status are marked as errors.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otelwrap.Import(r.Context(), headerToMap(r.Header))
+
+		route := r.URL.Path
+		ctx, span := otelwrap.StartServerWithOptions(ctx, []string{r.Method, route}, otelwrap.WithCallerSkip(1))
+		defer span.End()
+		span.SetAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.HTTPRoute(route),
+		)
+
+		rw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rw.status))
+		if rw.status >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(rw.status))
+		}
+	})
+}
+
+// Transport wraps base, starting a client span for every outgoing request
+// and exporting the current trace context into the request headers via
+// otelwrap.Export.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otelwrap.StartClientWithOptions(req.Context(), []string{req.Method, req.URL.Path}, otelwrap.WithCallerSkip(1))
+	defer span.End()
+	span.SetAttributes(
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+	)
+
+	req = req.Clone(ctx)
+	for key, value := range otelwrap.Export(ctx) {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// statusWriter captures the status code written by the wrapped handler,
+// since http.ResponseWriter does not expose it after the fact.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// headerToMap flattens an http.Header into the map[string]string that
+// otelwrap.Import expects, keeping only the first value of any repeated
+// header. Keys are lowercased since the W3C TraceContext propagator looks
+// up "traceparent"/"tracestate" in lowercase, while http.Header canonicalizes
+// keys to e.g. "Traceparent".
+func headerToMap(h http.Header) map[string]string {
+	index := make(map[string]string, len(h))
+	for key := range h {
+		index[strings.ToLower(key)] = h.Get(key)
+	}
+	return index
+}
@@ -0,0 +1,83 @@
+// Package otelwrapkafka propagates trace context through Kafka message
+// headers and traces producing/consuming messages, built on otelwrap.
+package otelwrapkafka
+
+import (
+	"context"
+
+	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sbunce/otelwrap"
+)
+
+// HeaderCarrier adapts a slice of kafka.Header to propagation.TextMapCarrier,
+// so a trace context can be propagated through Kafka message headers without
+// allocating an intermediate map.
+type HeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+// NewHeaderCarrier wraps headers so otelwrap.InjectInto/ExtractFrom can read
+// and write trace context directly against it.
+func NewHeaderCarrier(headers *[]kafka.Header) HeaderCarrier {
+	return HeaderCarrier{headers: headers}
+}
+
+// Get implements propagation.TextMapCarrier.
+func (c HeaderCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set implements propagation.TextMapCarrier.
+func (c HeaderCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// StartProducer traces sending a message to topic. It sets messaging
+// semconv attributes and injects the trace context into headers so the
+// consumer can continue the trace.
+func StartProducer(ctx context.Context, topic string, headers *[]kafka.Header) (context.Context, *otelwrap.Span) {
+	ctx, span := otelwrap.StartProducerWithOptions(ctx, []string{topic}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination", topic),
+		attribute.String("messaging.operation", "publish"),
+	)
+	otelwrap.InjectInto(ctx, NewHeaderCarrier(headers))
+	return ctx, span
+}
+
+// StartConsumer traces receiving a message from topic. It extracts the
+// producer's trace context from headers before starting the span, so the
+// consumer span is linked to the producer that sent the message.
+func StartConsumer(ctx context.Context, topic string, headers []kafka.Header) (context.Context, *otelwrap.Span) {
+	ctx = otelwrap.ExtractFrom(ctx, NewHeaderCarrier(&headers))
+	ctx, span := otelwrap.StartConsumerWithOptions(ctx, []string{topic}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination", topic),
+		attribute.String("messaging.operation", "receive"),
+	)
+	return ctx, span
+}
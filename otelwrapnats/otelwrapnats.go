@@ -0,0 +1,67 @@
+// Package otelwrapnats propagates trace context through NATS message
+// headers and traces producing/consuming messages, built on otelwrap.
+package otelwrapnats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sbunce/otelwrap"
+)
+
+// HeaderCarrier adapts a nats.Header to propagation.TextMapCarrier, so a
+// trace context can be propagated through NATS message headers without
+// allocating an intermediate map.
+type HeaderCarrier nats.Header
+
+// Get implements propagation.TextMapCarrier.
+func (h HeaderCarrier) Get(key string) string {
+	return nats.Header(h).Get(key)
+}
+
+// Set implements propagation.TextMapCarrier.
+func (h HeaderCarrier) Set(key, value string) {
+	nats.Header(h).Set(key, value)
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (h HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// StartProducer traces publishing msg to its subject. It sets messaging
+// semconv attributes and injects the trace context into msg.Header so the
+// consumer can continue the trace.
+func StartProducer(ctx context.Context, msg *nats.Msg) (context.Context, *otelwrap.Span) {
+	ctx, span := otelwrap.StartProducerWithOptions(ctx, []string{msg.Subject}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "nats"),
+		attribute.String("messaging.destination", msg.Subject),
+		attribute.String("messaging.operation", "publish"),
+	)
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	otelwrap.InjectInto(ctx, HeaderCarrier(msg.Header))
+	return ctx, span
+}
+
+// StartConsumer traces receiving msg. It extracts the producer's trace
+// context from msg.Header before starting the span, so the consumer span is
+// linked to the producer that sent the message.
+func StartConsumer(ctx context.Context, msg *nats.Msg) (context.Context, *otelwrap.Span) {
+	ctx = otelwrap.ExtractFrom(ctx, HeaderCarrier(msg.Header))
+	ctx, span := otelwrap.StartConsumerWithOptions(ctx, []string{msg.Subject}, otelwrap.WithCallerSkip(1))
+	span.SetAttributes(
+		attribute.String("messaging.system", "nats"),
+		attribute.String("messaging.destination", msg.Subject),
+		attribute.String("messaging.operation", "receive"),
+	)
+	return ctx, span
+}
@@ -0,0 +1,45 @@
+package otelwrap
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// InitOTLPHTTP is a turnkey Init for the common case of shipping traces to an
+// OTLP/HTTP collector. It builds a batching SDK TracerProvider, registers it
+// and the TraceContext+Baggage propagators, and sets it as the package
+// default tracer under serviceName. Callers must call the returned
+// Shutdown(ctx) before the process exits to flush buffered spans.
+func InitOTLPHTTP(ctx context.Context, endpoint, serviceName string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("creating otlp/http exporter: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("merging resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	Init(
+		WithTracerProvider(tp),
+		WithTracerName(serviceName, ""),
+		WithPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})),
+	)
+
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,24 @@
+package otelwrap
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// InjectInto writes the trace context carried by ctx into carrier. Use this
+// instead of Export when the destination already has (or can cheaply
+// provide) a propagation.TextMapCarrier, to avoid allocating an intermediate
+// map[string]string.
+func InjectInto(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// ExtractFrom reads a trace context out of carrier and returns a context
+// carrying it. Use this instead of Import when the source already has (or
+// can cheaply provide) a propagation.TextMapCarrier, to avoid allocating an
+// intermediate map[string]string.
+func ExtractFrom(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,88 @@
+package otelwrap
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SetAttributes attaches key/value attributes to the span.
+func (s *Span) SetAttributes(kv ...attribute.KeyValue) {
+	if s.noop {
+		return
+	}
+	s.span.SetAttributes(kv...)
+}
+
+// SetStatus sets the span's status code and description. Use this to mark a
+// span as failed without also recording an error via RecordError.
+func (s *Span) SetStatus(code codes.Code, description string) {
+	if s.noop {
+		return
+	}
+	s.span.SetStatus(code, description)
+}
+
+// AddEventKV records an event with an attached key/value payload. If a
+// logger was configured via WithLogger/WithLoggerBackend, the event is also
+// logged at info level, tagged with the span's trace_id/span_id.
+func (s *Span) AddEventKV(name string, kv ...attribute.KeyValue) {
+	if s.noop {
+		return
+	}
+	s.span.AddEvent(name, trace.WithAttributes(kv...))
+	logEvent(s.span, slog.LevelInfo, name, kv...)
+}
+
+// RecordErrorOption configures RecordError.
+type RecordErrorOption func(*recordErrorConfig)
+
+type recordErrorConfig struct {
+	stackTrace bool
+	setStatus  bool
+}
+
+// WithStackTrace controls whether the error's stack trace is attached to the
+// recorded event as the exception.stacktrace attribute. Off by default,
+// since capturing a stack trace is not free.
+func WithStackTrace(enabled bool) RecordErrorOption {
+	return func(c *recordErrorConfig) {
+		c.stackTrace = enabled
+	}
+}
+
+// WithoutStatus stops RecordError from also calling SetStatus(codes.Error,
+// err.Error()). Useful when the caller wants to record an error without
+// marking the whole span as failed.
+func WithoutStatus() RecordErrorOption {
+	return func(c *recordErrorConfig) {
+		c.setStatus = false
+	}
+}
+
+// RecordError records an error in the span. By default this also calls
+// SetStatus(codes.Error, err.Error()), matching how most callers use
+// RecordError; pass WithoutStatus to opt out. If a logger was configured
+// via WithLogger/WithLoggerBackend, the error is also logged at error level,
+// tagged with the span's trace_id/span_id.
+func (s *Span) RecordError(err error, opts ...RecordErrorOption) {
+	if s.noop || err == nil {
+		return
+	}
+	c := recordErrorConfig{setStatus: true}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var traceOpts []trace.EventOption
+	if c.stackTrace {
+		traceOpts = append(traceOpts, trace.WithStackTrace(true))
+	}
+	s.span.RecordError(err, traceOpts...)
+	if c.setStatus {
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	logEvent(s.span, slog.LevelError, err.Error())
+}
@@ -0,0 +1,133 @@
+package otelwrap
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startConfig accumulates the StartOption values passed to a *WithOptions
+// call.
+type startConfig struct {
+	spanOpts     []trace.SpanStartOption
+	remoteParent *trace.SpanContext
+	callerSkip   int
+}
+
+// StartOption configures a Start*WithOptions call.
+type StartOption interface {
+	applyStart(*startConfig)
+}
+
+type startOptionFunc func(*startConfig)
+
+func (f startOptionFunc) applyStart(c *startConfig) {
+	f(c)
+}
+
+// WithLinks adds links to the span being started, for example to join a
+// span to the producers that fan into it.
+func WithLinks(links ...trace.Link) StartOption {
+	return startOptionFunc(func(c *startConfig) {
+		c.spanOpts = append(c.spanOpts, trace.WithLinks(links...))
+	})
+}
+
+// WithTimestamp sets an explicit start time for the span, for example when
+// backdating a span to when a queued message was originally published.
+func WithTimestamp(t time.Time) StartOption {
+	return startOptionFunc(func(c *startConfig) {
+		c.spanOpts = append(c.spanOpts, trace.WithTimestamp(t))
+	})
+}
+
+// WithAttributes sets initial attributes on the span being started.
+func WithAttributes(kv ...attribute.KeyValue) StartOption {
+	return startOptionFunc(func(c *startConfig) {
+		c.spanOpts = append(c.spanOpts, trace.WithAttributes(kv...))
+	})
+}
+
+// WithRemoteParent uses sc as the parent span context instead of whatever
+// span is already in ctx, for example a SpanContext extracted from an
+// incoming request or queue message via Import.
+func WithRemoteParent(sc trace.SpanContext) StartOption {
+	return startOptionFunc(func(c *startConfig) {
+		c.remoteParent = &sc
+	})
+}
+
+// WithCallerSkip adds extra stack frames to skip when resolving the span's
+// func/file:line attribute. Start*WithOptions normally attributes the span
+// to its immediate caller; a wrapper that starts spans on behalf of
+// application code one or more frames up (for example otelwrapkafka's
+// StartProducer) should pass
+// WithCallerSkip(1) per frame it adds, so the span still points at the
+// application's call site instead of into the wrapper itself.
+func WithCallerSkip(skip int) StartOption {
+	return startOptionFunc(func(c *startConfig) {
+		c.callerSkip += skip
+	})
+}
+
+// StartInternalWithOptions trace within a process. Params show in the span
+// name.
+func StartInternalWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return defaultTracer.startSpan(ctx, trace.SpanKindInternal, params, opts...)
+}
+
+// StartClientWithOptions trace when calling another process. Params show in
+// the span name.
+func StartClientWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return defaultTracer.startSpan(ctx, trace.SpanKindClient, params, opts...)
+}
+
+// StartConsumerWithOptions trace when consuming from a pub/sub system. Params
+// show in the span name.
+func StartConsumerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return defaultTracer.startSpan(ctx, trace.SpanKindConsumer, params, opts...)
+}
+
+// StartProducerWithOptions trace when sending to a pub/sub system. Params
+// show in the span name.
+func StartProducerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return defaultTracer.startSpan(ctx, trace.SpanKindProducer, params, opts...)
+}
+
+// StartServerWithOptions trace when another process is calling us. Params
+// show in the span name.
+func StartServerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return defaultTracer.startSpan(ctx, trace.SpanKindServer, params, opts...)
+}
+
+// StartInternalWithOptions trace within a process. Params show in the span
+// name.
+func (t *Tracer) StartInternalWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindInternal, params, opts...)
+}
+
+// StartClientWithOptions trace when calling another process. Params show in
+// the span name.
+func (t *Tracer) StartClientWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindClient, params, opts...)
+}
+
+// StartConsumerWithOptions trace when consuming from a pub/sub system. Params
+// show in the span name.
+func (t *Tracer) StartConsumerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindConsumer, params, opts...)
+}
+
+// StartProducerWithOptions trace when sending to a pub/sub system. Params
+// show in the span name.
+func (t *Tracer) StartProducerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindProducer, params, opts...)
+}
+
+// StartServerWithOptions trace when another process is calling us. Params
+// show in the span name.
+func (t *Tracer) StartServerWithOptions(ctx context.Context, params []string, opts ...StartOption) (context.Context, *Span) {
+	return t.startSpan(ctx, trace.SpanKindServer, params, opts...)
+}